@@ -0,0 +1,225 @@
+package welford
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DecayingAggregate is an extension of the basic Aggregate which applies an
+// exponential forgetting factor before each update, so that older samples
+// fade out and the reported statistics track the recent behaviour of the
+// random variable rather than its entire history.
+type DecayingAggregate struct {
+	alpha float64
+	count float64
+	mean  float64
+	m2    float64
+}
+
+// ConcurrentDecayingAggregate is an extension of the basic DecayingAggregate
+// where it allows for concurrent access by multiple go-routines or producers.
+type ConcurrentDecayingAggregate struct {
+	DecayingAggregate
+	mu *sync.RWMutex
+}
+
+var (
+	_ WelfordAggregate = (*DecayingAggregate)(nil)
+	_ WelfordAggregate = (*ConcurrentDecayingAggregate)(nil)
+)
+
+// NewDecayingAggregate
+//
+// This function creates and initializes a new DecayingAggregate value, ready
+// to be used. Alpha is the exponential forgetting factor applied before each
+// update, and must lie in the range (0, 1]; a value of 1 disables decay
+// entirely, reducing to the behaviour of a plain Aggregate.
+func NewDecayingAggregate(Alpha float64) *DecayingAggregate {
+	return &DecayingAggregate{
+		alpha: Alpha,
+	}
+}
+
+// Reset
+//
+// This function resets an existing DecayingAggregate back to a zero-value,
+// readying it to be used on a new random sequence. The configured decay
+// factor is preserved.
+func (A *DecayingAggregate) Reset() WelfordWriter {
+
+	A.count = 0
+	A.mean = 0
+	A.m2 = 0
+
+	return A
+}
+
+// Update
+//
+// This function accepts a new random sample, and updates the internal state
+// of the DecayingAggregate to account for the newly provided sample value,
+// decaying the contribution of all previously observed samples.
+func (A *DecayingAggregate) Update(Values ...float64) WelfordWriter {
+
+	for _, v := range Values {
+		A = A.update(v)
+	}
+
+	return A
+}
+
+func (A *DecayingAggregate) update(Value float64) *DecayingAggregate {
+
+	A.count = A.alpha*A.count + 1
+	Delta := Value - A.mean
+	A.mean += Delta / A.count
+	Delta2 := Value - A.mean
+	A.m2 = A.alpha*A.m2 + Delta*Delta2
+
+	return A
+}
+
+func (A *DecayingAggregate) Count() int {
+	return int(A.count)
+}
+
+// EffectiveCount
+//
+// This returns the un-truncated, decay-weighted effective sample size, which
+// converges to 1/(1-alpha) as more samples are observed.
+func (A *DecayingAggregate) EffectiveCount() float64 {
+	return A.count
+}
+
+func (A *DecayingAggregate) Mean() float64 {
+	return A.mean
+}
+
+func (A *DecayingAggregate) Variance() float64 {
+
+	if A.count == 0 {
+		return 0
+	}
+
+	return A.m2 / A.count
+}
+
+func (A *DecayingAggregate) SampleVariance() float64 {
+
+	if A.count <= 1 {
+		return 0
+	}
+
+	return A.m2 / (A.count - 1)
+}
+
+func (A *DecayingAggregate) Results() (int, float64, float64, float64) {
+	return A.Count(), A.Mean(), A.Variance(), A.SampleVariance()
+}
+
+func (A *DecayingAggregate) String() string {
+
+	Count, Mean, Variance, SampleVariance := A.Results()
+
+	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
+}
+
+// NewConcurrentDecayingAggregate
+//
+// This function creates and initializes a new ConcurrentDecayingAggregate
+// value, ready to be used.
+func NewConcurrentDecayingAggregate(Alpha float64) *ConcurrentDecayingAggregate {
+	return &ConcurrentDecayingAggregate{
+		DecayingAggregate: DecayingAggregate{
+			alpha: Alpha,
+		},
+		mu: &sync.RWMutex{},
+	}
+}
+
+// Reset
+//
+// This function resets an existing ConcurrentDecayingAggregate back to a
+// zero-value, readying it to be used on a new random sequence.
+func (A *ConcurrentDecayingAggregate) Reset() WelfordWriter {
+
+	A.mu.Lock()
+	defer A.mu.Unlock()
+
+	A.DecayingAggregate.Reset()
+
+	return A
+}
+
+// Update
+//
+// This function accepts a new random sample, and updates the internal state
+// of the ConcurrentDecayingAggregate to account for the newly provided sample
+// value.
+func (A *ConcurrentDecayingAggregate) Update(Values ...float64) WelfordWriter {
+
+	A.mu.Lock()
+	defer A.mu.Unlock()
+
+	A.DecayingAggregate.Update(Values...)
+	return A
+}
+
+func (A *ConcurrentDecayingAggregate) Count() int {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.Count()
+}
+
+// EffectiveCount
+//
+// This returns the un-truncated, decay-weighted effective sample size, which
+// converges to 1/(1-alpha) as more samples are observed.
+func (A *ConcurrentDecayingAggregate) EffectiveCount() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.EffectiveCount()
+}
+
+func (A *ConcurrentDecayingAggregate) Mean() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.Mean()
+}
+
+func (A *ConcurrentDecayingAggregate) Variance() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.Variance()
+}
+
+func (A *ConcurrentDecayingAggregate) SampleVariance() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.SampleVariance()
+}
+
+func (A *ConcurrentDecayingAggregate) Results() (int, float64, float64, float64) {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.DecayingAggregate.Results()
+}
+
+func (A *ConcurrentDecayingAggregate) String() string {
+
+	Count, Mean, Variance, SampleVariance := A.Results()
+
+	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
+}
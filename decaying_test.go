@@ -0,0 +1,82 @@
+package welford
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestDecayingAggregateNoDecayMatchesAggregate(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	Plain := NewAggregate()
+	Plain.Update(Data...)
+
+	// Alpha == 1 disables decay entirely, so this should reduce to a plain
+	// Aggregate over the same samples.
+	Decaying := NewDecayingAggregate(1)
+	Decaying.Update(Data...)
+
+	if math.Abs(Decaying.Mean()-Plain.Mean()) > 1e-9 {
+		t.Fatalf("mean mismatch: got %f want %f", Decaying.Mean(), Plain.Mean())
+	}
+	if math.Abs(Decaying.Variance()-Plain.Variance()) > 1e-9 {
+		t.Fatalf("variance mismatch: got %f want %f", Decaying.Variance(), Plain.Variance())
+	}
+}
+
+func TestDecayingAggregateForgetsOldSamples(t *testing.T) {
+
+	A := NewDecayingAggregate(0.5)
+	for i := 0; i < 100; i++ {
+		A.Update(0)
+	}
+	A.Update(100)
+
+	// With aggressive decay, a single large new sample should dominate the
+	// mean far more than it would in an un-decayed running average.
+	if Mean := A.Mean(); Mean < 30 {
+		t.Fatalf("expected decayed mean to be pulled strongly towards the new sample, got %f", Mean)
+	}
+
+	if EffectiveCount := A.EffectiveCount(); EffectiveCount >= 3 {
+		t.Fatalf("expected effective count to converge near 1/(1-alpha)=2, got %f", EffectiveCount)
+	}
+}
+
+func TestDecayingAggregateReset(t *testing.T) {
+
+	A := NewDecayingAggregate(0.9)
+	A.Update(1, 2, 3)
+	A.Reset()
+
+	if Count, Mean, Variance, SampleVariance := A.Results(); Count != 0 || Mean != 0 || Variance != 0 || SampleVariance != 0 {
+		t.Fatalf("expected zero-value after Reset, got (%d, %f, %f, %f)", Count, Mean, Variance, SampleVariance)
+	}
+}
+
+func TestConcurrentDecayingAggregateRace(t *testing.T) {
+
+	A := NewConcurrentDecayingAggregate(0.9)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(v float64) {
+			defer wg.Done()
+			A.Update(v)
+		}(float64(i))
+	}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = A.Count()
+			_ = A.Mean()
+			_ = A.EffectiveCount()
+			_ = A.String()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,193 @@
+package welford
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// aggregateBinaryVersion identifies the layout produced by MarshalBinary, so
+// that future, incompatible layouts can be detected on Decode rather than
+// silently misread.
+const aggregateBinaryVersion uint8 = 1
+
+// aggregateBinarySize is the fixed, on-the-wire size in bytes of an encoded
+// Aggregate: a version byte, five big-endian float64/int64 fields
+// (count, mean, m2, m3, m4), and a trailing CRC-32 checksum of everything
+// preceding it.
+const aggregateBinarySize = 1 + 8*5 + 4
+
+// MarshalBinary encodes the Aggregate into the stable binary format described
+// by aggregateBinarySize, suitable for checkpointing to disk or shipping to
+// another process for later merging with Combine.
+func (A *Aggregate) MarshalBinary() ([]byte, error) {
+
+	Data := make([]byte, aggregateBinarySize)
+
+	Data[0] = aggregateBinaryVersion
+	binary.BigEndian.PutUint64(Data[1:9], uint64(A.count))
+	binary.BigEndian.PutUint64(Data[9:17], math.Float64bits(A.mean))
+	binary.BigEndian.PutUint64(Data[17:25], math.Float64bits(A.m2))
+	binary.BigEndian.PutUint64(Data[25:33], math.Float64bits(A.m3))
+	binary.BigEndian.PutUint64(Data[33:41], math.Float64bits(A.m4))
+
+	Checksum := crc32.ChecksumIEEE(Data[:41])
+	binary.BigEndian.PutUint32(Data[41:45], Checksum)
+
+	return Data, nil
+}
+
+// UnmarshalBinary decodes an Aggregate previously produced by MarshalBinary,
+// verifying both the version byte and the trailing checksum before mutating
+// the receiver.
+func (A *Aggregate) UnmarshalBinary(Data []byte) error {
+
+	if len(Data) != aggregateBinarySize {
+		return fmt.Errorf("welford: invalid Aggregate encoding: expected %d bytes, got %d", aggregateBinarySize, len(Data))
+	}
+
+	if Version := Data[0]; Version != aggregateBinaryVersion {
+		return fmt.Errorf("welford: unsupported Aggregate encoding version %d", Version)
+	}
+
+	Body, Checksum := Data[:41], binary.BigEndian.Uint32(Data[41:45])
+	if crc32.ChecksumIEEE(Body) != Checksum {
+		return fmt.Errorf("welford: corrupt Aggregate encoding: checksum mismatch")
+	}
+
+	A.count = int(binary.BigEndian.Uint64(Data[1:9]))
+	A.mean = math.Float64frombits(binary.BigEndian.Uint64(Data[9:17]))
+	A.m2 = math.Float64frombits(binary.BigEndian.Uint64(Data[17:25]))
+	A.m3 = math.Float64frombits(binary.BigEndian.Uint64(Data[25:33]))
+	A.m4 = math.Float64frombits(binary.BigEndian.Uint64(Data[33:41]))
+
+	return nil
+}
+
+// aggregateJSON is the JSON-facing mirror of Aggregate's internal state, used
+// by MarshalJSON/UnmarshalJSON so the unexported fields of Aggregate need not
+// be exported to support JSON encoding.
+type aggregateJSON struct {
+	Version uint8   `json:"version"`
+	Count   int     `json:"count"`
+	Mean    float64 `json:"mean"`
+	M2      float64 `json:"m2"`
+	M3      float64 `json:"m3"`
+	M4      float64 `json:"m4"`
+}
+
+// MarshalJSON encodes the Aggregate as JSON, carrying the same fields as
+// MarshalBinary.
+func (A *Aggregate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aggregateJSON{
+		Version: aggregateBinaryVersion,
+		Count:   A.count,
+		Mean:    A.mean,
+		M2:      A.m2,
+		M3:      A.m3,
+		M4:      A.m4,
+	})
+}
+
+// UnmarshalJSON decodes an Aggregate previously produced by MarshalJSON,
+// verifying the version field before mutating the receiver.
+func (A *Aggregate) UnmarshalJSON(Data []byte) error {
+
+	var Decoded aggregateJSON
+	if err := json.Unmarshal(Data, &Decoded); err != nil {
+		return err
+	}
+
+	if Decoded.Version != aggregateBinaryVersion {
+		return fmt.Errorf("welford: unsupported Aggregate encoding version %d", Decoded.Version)
+	}
+
+	A.count = Decoded.Count
+	A.mean = Decoded.Mean
+	A.m2 = Decoded.M2
+	A.m3 = Decoded.M3
+	A.m4 = Decoded.M4
+
+	return nil
+}
+
+// Encode writes the stable binary encoding of A to w. See MarshalBinary for
+// the wire format.
+func Encode(w io.Writer, A *Aggregate) error {
+
+	Data, err := A.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(Data)
+	return err
+}
+
+// Decode reads a single Aggregate previously written by Encode from r.
+//
+// Decode pairs naturally with Combine for fanning computation of a single
+// Aggregate out across multiple processes or files, and folding the partial
+// results back together once every worker has finished. For example, using
+// golang.org/x/sync/errgroup to encode one partial Aggregate per input file
+// and merging them on the coordinator:
+//
+//	func AggregateFiles(ctx context.Context, paths []string) (*welford.Aggregate, error) {
+//
+//		Partials := make([]*welford.Aggregate, len(paths))
+//
+//		g, _ := errgroup.WithContext(ctx)
+//		for i, path := range paths {
+//			i, path := i, path
+//			g.Go(func() error {
+//				Values, err := readValues(path)
+//				if err != nil {
+//					return err
+//				}
+//
+//				Local := welford.NewAggregate()
+//				Local.Update(Values...)
+//
+//				var Buffer bytes.Buffer
+//				if err := welford.Encode(&Buffer, Local); err != nil {
+//					return err
+//				}
+//
+//				Partial, err := welford.Decode(&Buffer)
+//				if err != nil {
+//					return err
+//				}
+//
+//				Partials[i] = Partial
+//				return nil
+//			})
+//		}
+//
+//		if err := g.Wait(); err != nil {
+//			return nil, err
+//		}
+//
+//		Master := welford.NewAggregate()
+//		for _, Partial := range Partials {
+//			Master.Combine(Partial)
+//		}
+//
+//		return Master, nil
+//	}
+func Decode(r io.Reader) (*Aggregate, error) {
+
+	Data := make([]byte, aggregateBinarySize)
+	if _, err := io.ReadFull(r, Data); err != nil {
+		return nil, err
+	}
+
+	A := NewAggregate()
+	if err := A.UnmarshalBinary(Data); err != nil {
+		return nil, err
+	}
+
+	return A, nil
+}
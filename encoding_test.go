@@ -0,0 +1,114 @@
+package welford
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestAggregateBinaryRoundTrip(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(1, 2, 3, 4, 5, 6.5, -2.3)
+
+	var Buffer bytes.Buffer
+	if err := Encode(&Buffer, A); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	B, err := Decode(&Buffer)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if math.Abs(A.Mean()-B.Mean()) > 1e-12 || math.Abs(A.Variance()-B.Variance()) > 1e-12 ||
+		math.Abs(A.Skewness()-B.Skewness()) > 1e-12 || math.Abs(A.Kurtosis()-B.Kurtosis()) > 1e-12 {
+		t.Fatalf("round trip mismatch: got %v want %v", B, A)
+	}
+}
+
+func TestAggregateUnmarshalBinaryDetectsCorruption(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(1, 2, 3)
+
+	Data, err := A.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	Data[10] ^= 0xFF
+
+	var B Aggregate
+	if err := B.UnmarshalBinary(Data); err == nil {
+		t.Fatal("expected checksum mismatch to be detected")
+	}
+}
+
+func TestAggregateUnmarshalBinaryRejectsWrongSize(t *testing.T) {
+
+	var A Aggregate
+	if err := A.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected undersized payload to be rejected")
+	}
+}
+
+func TestAggregateJSONRoundTrip(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(10, 20, 30, 40)
+
+	Data, err := json.Marshal(A)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var B Aggregate
+	if err := json.Unmarshal(Data, &B); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if A.Count() != B.Count() || math.Abs(A.Mean()-B.Mean()) > 1e-12 {
+		t.Fatalf("json round trip mismatch: got %v want %v", &B, A)
+	}
+}
+
+func TestDecodeMergesAcrossEncodedChunks(t *testing.T) {
+
+	Chunks := [][]float64{
+		{1, 2, 3},
+		{4, 5, 6, 7},
+		{8, 9},
+	}
+
+	Master := NewAggregate()
+	for _, Chunk := range Chunks {
+		Partial := NewAggregate()
+		Partial.Update(Chunk...)
+
+		var Buffer bytes.Buffer
+		if err := Encode(&Buffer, Partial); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		Decoded, err := Decode(&Buffer)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+
+		Master.Combine(Decoded)
+	}
+
+	Direct := NewAggregate()
+	for _, Chunk := range Chunks {
+		Direct.Update(Chunk...)
+	}
+
+	if Master.Count() != Direct.Count() {
+		t.Fatalf("count mismatch: got %d want %d", Master.Count(), Direct.Count())
+	}
+	if math.Abs(Master.Mean()-Direct.Mean()) > 1e-9 {
+		t.Fatalf("mean mismatch: got %f want %f", Master.Mean(), Direct.Mean())
+	}
+}
@@ -0,0 +1,131 @@
+package welford
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelAggregate is a lock-free-on-the-write-path alternative to
+// ConcurrentAggregate, intended for high-throughput metric-ingest pipelines
+// where many producers would otherwise contend on a single mutex. Samples are
+// fanned out over a buffered channel to a pool of worker go-routines, each of
+// which owns its own local Aggregate, and the per-worker Aggregates are
+// folded together with Combine each time Results or Flush is called. No
+// merging happens in the background, and no master Aggregate is kept between
+// calls.
+type ParallelAggregate struct {
+	input   chan float64
+	workers []*Aggregate
+	mus     []sync.Mutex
+	pending int64
+	wg      sync.WaitGroup
+}
+
+// NewParallelAggregate
+//
+// This function creates and initializes a new ParallelAggregate, spawning
+// Workers go-routines which consume samples from a channel buffered to
+// BufferSize. The returned value is ready to be used. Workers is clamped to
+// a minimum of 1 and BufferSize to a minimum of 0, so that a non-positive
+// caller-supplied value can neither panic on creation nor leave no worker
+// around to drain Update, which would otherwise deadlock once the buffer
+// filled.
+func NewParallelAggregate(Workers int, BufferSize int) *ParallelAggregate {
+
+	if Workers < 1 {
+		Workers = 1
+	}
+	if BufferSize < 0 {
+		BufferSize = 0
+	}
+
+	P := &ParallelAggregate{
+		input:   make(chan float64, BufferSize),
+		workers: make([]*Aggregate, Workers),
+		mus:     make([]sync.Mutex, Workers),
+	}
+
+	for i := range P.workers {
+		P.workers[i] = NewAggregate()
+	}
+
+	P.wg.Add(Workers)
+	for i := 0; i < Workers; i++ {
+		go P.run(i)
+	}
+
+	return P
+}
+
+func (P *ParallelAggregate) run(ID int) {
+
+	defer P.wg.Done()
+
+	for Value := range P.input {
+		P.mus[ID].Lock()
+		P.workers[ID].update(Value)
+		P.mus[ID].Unlock()
+		atomic.AddInt64(&P.pending, -1)
+	}
+}
+
+// Update
+//
+// This function accepts one or more new random samples, and fans them out to
+// the worker pool for processing. This never blocks on a shared mutex; the
+// only blocking which can occur is backpressure from a full buffered channel.
+func (P *ParallelAggregate) Update(Values ...float64) *ParallelAggregate {
+
+	atomic.AddInt64(&P.pending, int64(len(Values)))
+
+	for _, v := range Values {
+		P.input <- v
+	}
+
+	return P
+}
+
+// Results
+//
+// This function folds the current state of every worker's local Aggregate
+// together with Combine, and returns the merged result. Samples which have
+// been sent to Update but not yet processed by a worker are not reflected.
+func (P *ParallelAggregate) Results() *Aggregate {
+
+	Master := NewAggregate()
+
+	for i := range P.workers {
+		P.mus[i].Lock()
+		Snapshot := *P.workers[i]
+		P.mus[i].Unlock()
+
+		Master.Combine(&Snapshot)
+	}
+
+	return Master
+}
+
+// Flush
+//
+// This function blocks until every sample previously passed to Update has
+// been processed by a worker, and then folds all worker Aggregates together
+// with Combine, returning the merged result.
+func (P *ParallelAggregate) Flush() *Aggregate {
+
+	for atomic.LoadInt64(&P.pending) > 0 {
+		runtime.Gosched()
+	}
+
+	return P.Results()
+}
+
+// Close
+//
+// This function closes the input channel and waits for every worker
+// go-routine to drain and exit. A ParallelAggregate must not be used after
+// Close has been called.
+func (P *ParallelAggregate) Close() {
+	close(P.input)
+	P.wg.Wait()
+}
@@ -0,0 +1,77 @@
+package welford
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestParallelAggregateFlushMatchesDirectUpdate(t *testing.T) {
+
+	P := NewParallelAggregate(4, 16)
+
+	var Data []float64
+	for i := 0; i < 500; i++ {
+		Data = append(Data, float64(i))
+	}
+
+	var wg sync.WaitGroup
+	for _, v := range Data {
+		wg.Add(1)
+		go func(v float64) {
+			defer wg.Done()
+			P.Update(v)
+		}(v)
+	}
+	wg.Wait()
+
+	Result := P.Flush()
+	P.Close()
+
+	Direct := NewAggregate()
+	Direct.Update(Data...)
+
+	if Result.Count() != Direct.Count() {
+		t.Fatalf("count mismatch: got %d want %d", Result.Count(), Direct.Count())
+	}
+	if math.Abs(Result.Mean()-Direct.Mean()) > 1e-6 {
+		t.Fatalf("mean mismatch: got %f want %f", Result.Mean(), Direct.Mean())
+	}
+	if math.Abs(Result.Variance()-Direct.Variance()) > 1e-6 {
+		t.Fatalf("variance mismatch: got %f want %f", Result.Variance(), Direct.Variance())
+	}
+}
+
+func TestNewParallelAggregateClampsNonPositiveInputs(t *testing.T) {
+
+	P := NewParallelAggregate(-3, -10)
+	defer P.Close()
+
+	if len(P.workers) != 1 {
+		t.Fatalf("expected Workers to be clamped to 1, got %d", len(P.workers))
+	}
+
+	// A clamped, single worker must still be able to accept and process an
+	// Update without deadlocking, even with a non-positive requested buffer.
+	P.Update(1, 2, 3)
+	Result := P.Flush()
+
+	if Result.Count() != 3 {
+		t.Fatalf("expected 3 samples processed, got %d", Result.Count())
+	}
+}
+
+func TestParallelAggregateZeroWorkersDoesNotDeadlock(t *testing.T) {
+
+	P := NewParallelAggregate(0, 0)
+	defer P.Close()
+
+	if len(P.workers) != 1 {
+		t.Fatalf("expected Workers == 0 to be clamped to 1, got %d", len(P.workers))
+	}
+
+	P.Update(1)
+	if Result := P.Flush(); Result.Count() != 1 {
+		t.Fatalf("expected 1 sample processed, got %d", Result.Count())
+	}
+}
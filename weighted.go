@@ -0,0 +1,234 @@
+package welford
+
+import (
+	"fmt"
+	"sync"
+)
+
+// WeightedAggregate is an extension of the basic Aggregate which allows each
+// sample to contribute a different weight to the running mean and variance,
+// following West's weighted variant of Welford's online algorithm.
+type WeightedAggregate struct {
+	count        int
+	totalWeight  float64
+	sumWeightsSq float64
+	mean         float64
+	m2           float64
+}
+
+// ConcurrentWeightedAggregate is an extension of the basic WeightedAggregate
+// where it allows for concurrent access by multiple go-routines or producers.
+type ConcurrentWeightedAggregate struct {
+	WeightedAggregate
+	mu *sync.RWMutex
+}
+
+var (
+	_ WelfordAggregate = (*WeightedAggregate)(nil)
+	_ WelfordAggregate = (*ConcurrentWeightedAggregate)(nil)
+)
+
+// NewWeightedAggregate
+//
+// This function creates and initializes a new WeightedAggregate value, ready
+// to be used.
+func NewWeightedAggregate() *WeightedAggregate {
+	return &WeightedAggregate{}
+}
+
+// Reset
+//
+// This function resets an existing WeightedAggregate back to a zero-value,
+// readying it to be used on a new random sequence.
+func (A *WeightedAggregate) Reset() WelfordWriter {
+
+	A.count = 0
+	A.totalWeight = 0
+	A.sumWeightsSq = 0
+	A.mean = 0
+	A.m2 = 0
+
+	return A
+}
+
+// Update
+//
+// This function accepts a new random sample, and updates the internal state
+// of the WeightedAggregate to account for the newly provided sample value.
+// Each value is treated as carrying a weight of 1.
+func (A *WeightedAggregate) Update(Values ...float64) WelfordWriter {
+
+	for _, v := range Values {
+		A = A.updateWeighted(v, 1)
+	}
+
+	return A
+}
+
+// UpdateWeighted
+//
+// This function accepts a new random sample along with its weight, and
+// updates the internal state of the WeightedAggregate to account for the
+// newly provided sample value.
+func (A *WeightedAggregate) UpdateWeighted(Value float64, Weight float64) *WeightedAggregate {
+	return A.updateWeighted(Value, Weight)
+}
+
+func (A *WeightedAggregate) updateWeighted(Value float64, Weight float64) *WeightedAggregate {
+
+	A.count++
+	A.totalWeight += Weight
+	A.sumWeightsSq += Weight * Weight
+
+	Delta := Value - A.mean
+	A.mean += (Weight / A.totalWeight) * Delta
+	Delta2 := Value - A.mean
+	A.m2 += Weight * Delta * Delta2
+
+	return A
+}
+
+func (A *WeightedAggregate) Count() int {
+	return A.count
+}
+
+func (A *WeightedAggregate) Mean() float64 {
+	return A.mean
+}
+
+func (A *WeightedAggregate) Variance() float64 {
+
+	if A.totalWeight == 0 {
+		return 0
+	}
+
+	return A.m2 / A.totalWeight
+}
+
+// SampleVariance
+//
+// This returns the reliability-weighted sample variance, which accounts for
+// the effective number of independent samples represented by the weights.
+func (A *WeightedAggregate) SampleVariance() float64 {
+
+	if A.totalWeight == 0 {
+		return 0
+	}
+
+	Denominator := A.totalWeight - (A.sumWeightsSq / A.totalWeight)
+	if Denominator <= 0 {
+		return 0
+	}
+
+	return A.m2 / Denominator
+}
+
+func (A *WeightedAggregate) Results() (int, float64, float64, float64) {
+	return A.Count(), A.Mean(), A.Variance(), A.SampleVariance()
+}
+
+func (A *WeightedAggregate) String() string {
+
+	Count, Mean, Variance, SampleVariance := A.Results()
+
+	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
+}
+
+// NewConcurrentWeightedAggregate
+//
+// This function creates and initializes a new ConcurrentWeightedAggregate
+// value, ready to be used.
+func NewConcurrentWeightedAggregate() *ConcurrentWeightedAggregate {
+	return &ConcurrentWeightedAggregate{
+		mu: &sync.RWMutex{},
+	}
+}
+
+// Reset
+//
+// This function resets an existing ConcurrentWeightedAggregate back to a
+// zero-value, readying it to be used on a new random sequence.
+func (A *ConcurrentWeightedAggregate) Reset() WelfordWriter {
+
+	A.mu.Lock()
+	defer A.mu.Unlock()
+
+	A.WeightedAggregate.Reset()
+
+	return A
+}
+
+// Update
+//
+// This function accepts a new random sample, and updates the internal state
+// of the ConcurrentWeightedAggregate to account for the newly provided sample
+// value.
+func (A *ConcurrentWeightedAggregate) Update(Values ...float64) WelfordWriter {
+
+	A.mu.Lock()
+	defer A.mu.Unlock()
+
+	A.WeightedAggregate.Update(Values...)
+	return A
+}
+
+// UpdateWeighted
+//
+// This function accepts a new random sample along with its weight, and
+// updates the internal state of the ConcurrentWeightedAggregate to account
+// for the newly provided sample value.
+func (A *ConcurrentWeightedAggregate) UpdateWeighted(Value float64, Weight float64) *ConcurrentWeightedAggregate {
+
+	A.mu.Lock()
+	defer A.mu.Unlock()
+
+	A.WeightedAggregate.UpdateWeighted(Value, Weight)
+	return A
+}
+
+func (A *ConcurrentWeightedAggregate) Count() int {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.WeightedAggregate.Count()
+}
+
+func (A *ConcurrentWeightedAggregate) Mean() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.WeightedAggregate.Mean()
+}
+
+func (A *ConcurrentWeightedAggregate) Variance() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.WeightedAggregate.Variance()
+}
+
+func (A *ConcurrentWeightedAggregate) SampleVariance() float64 {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.WeightedAggregate.SampleVariance()
+}
+
+func (A *ConcurrentWeightedAggregate) Results() (int, float64, float64, float64) {
+
+	A.mu.RLock()
+	defer A.mu.RUnlock()
+
+	return A.WeightedAggregate.Results()
+}
+
+func (A *ConcurrentWeightedAggregate) String() string {
+
+	Count, Mean, Variance, SampleVariance := A.Results()
+
+	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
+}
@@ -0,0 +1,82 @@
+package welford
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestWeightedAggregateUnitWeightsMatchAggregate(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	Plain := NewAggregate()
+	Plain.Update(Data...)
+
+	Weighted := NewWeightedAggregate()
+	for _, v := range Data {
+		Weighted.UpdateWeighted(v, 1)
+	}
+
+	if Weighted.Count() != Plain.Count() {
+		t.Fatalf("count mismatch: got %d want %d", Weighted.Count(), Plain.Count())
+	}
+	if math.Abs(Weighted.Mean()-Plain.Mean()) > 1e-9 {
+		t.Fatalf("mean mismatch: got %f want %f", Weighted.Mean(), Plain.Mean())
+	}
+	if math.Abs(Weighted.Variance()-Plain.Variance()) > 1e-9 {
+		t.Fatalf("variance mismatch: got %f want %f", Weighted.Variance(), Plain.Variance())
+	}
+}
+
+func TestWeightedAggregateWeightsBiasMean(t *testing.T) {
+
+	A := NewWeightedAggregate()
+	A.UpdateWeighted(0, 1)
+	A.UpdateWeighted(10, 9)
+
+	// A heavily-weighted sample should pull the mean much closer to it than
+	// an unweighted average of the same two values (which would be 5).
+	if Mean := A.Mean(); Mean <= 7 || Mean >= 10 {
+		t.Fatalf("expected mean pulled towards heavily-weighted sample, got %f", Mean)
+	}
+}
+
+func TestWeightedAggregateReset(t *testing.T) {
+
+	A := NewWeightedAggregate()
+	A.UpdateWeighted(1, 2)
+	A.Reset()
+
+	if Count, Mean, Variance, SampleVariance := A.Results(); Count != 0 || Mean != 0 || Variance != 0 || SampleVariance != 0 {
+		t.Fatalf("expected zero-value after Reset, got (%d, %f, %f, %f)", Count, Mean, Variance, SampleVariance)
+	}
+}
+
+func TestConcurrentWeightedAggregateRace(t *testing.T) {
+
+	A := NewConcurrentWeightedAggregate()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(v float64) {
+			defer wg.Done()
+			A.UpdateWeighted(v, 2)
+		}(float64(i))
+	}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = A.Count()
+			_ = A.Mean()
+			_ = A.String()
+		}()
+	}
+	wg.Wait()
+
+	if A.Count() != 50 {
+		t.Fatalf("expected 50 updates, got %d", A.Count())
+	}
+}
@@ -2,12 +2,23 @@ package welford
 
 import (
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 )
 
-type WelfordAggregate interface {
-	Reset() WelfordAggregate
-	Update(...float64) WelfordAggregate
+// WelfordWriter is the write-side of a Welford aggregate: accepting new
+// samples and resetting accumulated state.
+type WelfordWriter interface {
+	Reset() WelfordWriter
+	Update(...float64) WelfordWriter
+}
+
+// WelfordSnapshot is an immutable, read-only view of a Welford aggregate's
+// state at a single point in time. Unlike a WelfordWriter, a WelfordSnapshot
+// is never mutated after it is produced, so it may be read freely without
+// synchronization.
+type WelfordSnapshot interface {
 	Count() int
 	Mean() float64
 	Variance() float64
@@ -16,6 +27,21 @@ type WelfordAggregate interface {
 	String() string
 }
 
+// WelfordAggregate is the union of WelfordWriter and WelfordSnapshot,
+// preserved for backwards compatibility with code written against the
+// combined interface.
+type WelfordAggregate interface {
+	WelfordWriter
+	WelfordSnapshot
+}
+
+var (
+	_ WelfordWriter    = (*Aggregate)(nil)
+	_ WelfordAggregate = (*Aggregate)(nil)
+	_ WelfordWriter    = (*ConcurrentAggregate)(nil)
+	_ WelfordAggregate = (*ConcurrentAggregate)(nil)
+)
+
 // Aggregate is an opaque struct which holds the current status of the online
 // calculation of mean and standard deviation of the corresponding random
 // variable.
@@ -23,13 +49,17 @@ type Aggregate struct {
 	count int
 	mean  float64
 	m2    float64
+	m3    float64
+	m4    float64
 }
 
 // ConcurrentAggregate is an extension of the basic Aggregate where it allows
-// for concurrent access by multiple go-routines or producers.
+// for concurrent access by multiple go-routines or producers. Reads are
+// served from an atomically-swapped, copy-on-write Snapshot, so readers never
+// block writers and writers never block each other on the read path.
 type ConcurrentAggregate struct {
-	Aggregate
-	mu *sync.RWMutex
+	current atomic.Pointer[Aggregate]
+	wmu     sync.Mutex
 }
 
 // NewAggregate
@@ -43,11 +73,13 @@ func NewAggregate() *Aggregate {
 //
 // This function resets an existing Aggregate back to a zero-value, readying it
 // to be used on a new random sequence.
-func (A *Aggregate) Reset() *Aggregate {
+func (A *Aggregate) Reset() WelfordWriter {
 
 	A.count = 0
 	A.mean = 0
 	A.m2 = 0
+	A.m3 = 0
+	A.m4 = 0
 
 	return A
 }
@@ -56,7 +88,7 @@ func (A *Aggregate) Reset() *Aggregate {
 //
 // This function accepts a new random sample, and updates the internal state of
 // the Aggregate to account for the newly provided sample value.
-func (A *Aggregate) Update(Values ...float64) *Aggregate {
+func (A *Aggregate) Update(Values ...float64) WelfordWriter {
 
 	for _, v := range Values {
 		A = A.update(v)
@@ -67,11 +99,19 @@ func (A *Aggregate) Update(Values ...float64) *Aggregate {
 
 func (A *Aggregate) update(Value float64) *Aggregate {
 
+	N1 := float64(A.count)
 	A.count++
+	N := float64(A.count)
+
 	Delta := Value - A.mean
-	A.mean += Delta / float64(A.count)
-	Delta2 := Value - A.mean
-	A.m2 += Delta * Delta2
+	DeltaN := Delta / N
+	DeltaN2 := DeltaN * DeltaN
+	Term1 := Delta * DeltaN * N1
+
+	A.m4 += Term1*DeltaN2*(N*N-3*N+3) + 6*DeltaN2*A.m2 - 4*DeltaN*A.m3
+	A.m3 += Term1*DeltaN*(N-2) - 3*DeltaN*A.m2
+	A.m2 += Term1
+	A.mean += DeltaN
 
 	return A
 }
@@ -106,6 +146,44 @@ func (A *Aggregate) Results() (int, float64, float64, float64) {
 	return A.Count(), A.Mean(), A.Variance(), A.SampleVariance()
 }
 
+// Skewness
+//
+// This function returns the sample skewness of the observed random variable,
+// a measure of the asymmetry of its distribution about the mean.
+func (A *Aggregate) Skewness() float64 {
+
+	if A.m2 == 0 {
+		return 0
+	}
+
+	return math.Sqrt(float64(A.count)) * A.m3 / math.Pow(A.m2, 1.5)
+}
+
+// Kurtosis
+//
+// This function returns the excess kurtosis of the observed random variable,
+// a measure of how heavy-tailed its distribution is relative to a normal
+// distribution, which has an excess kurtosis of 0.
+func (A *Aggregate) Kurtosis() float64 {
+
+	if A.m2 == 0 {
+		return 0
+	}
+
+	return float64(A.count)*A.m4/(A.m2*A.m2) - 3
+}
+
+// ResultsExtended
+//
+// This function returns every statistic tracked by the Aggregate, including
+// the higher-order moments not exposed by Results.
+func (A *Aggregate) ResultsExtended() (int, float64, float64, float64, float64, float64) {
+
+	Count, Mean, Variance, SampleVariance := A.Results()
+
+	return Count, Mean, Variance, SampleVariance, A.Skewness(), A.Kurtosis()
+}
+
 func (A *Aggregate) String() string {
 
 	Count, Mean, Variance, SampleVariance := A.Results()
@@ -113,25 +191,73 @@ func (A *Aggregate) String() string {
 	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
 }
 
+// Combine
+//
+// This function merges another Aggregate into the receiver using Chan et
+// al.'s parallel-variance algorithm, producing the same result as if every
+// sample observed by Other had instead been observed by A directly. This
+// allows independently-accumulated Aggregates, for example from separate
+// goroutines or separate chunks of a dataset, to be folded together.
+func (A *Aggregate) Combine(Other *Aggregate) *Aggregate {
+
+	if Other == nil || Other.count == 0 {
+		return A
+	}
+
+	if A.count == 0 {
+		*A = *Other
+		return A
+	}
+
+	NA, NB := float64(A.count), float64(Other.count)
+	N := NA + NB
+
+	Delta := Other.mean - A.mean
+	Delta2 := Delta * Delta
+	Delta3 := Delta2 * Delta
+	Delta4 := Delta3 * Delta
+
+	M2 := A.m2 + Other.m2 + Delta2*NA*NB/N
+
+	M3 := A.m3 + Other.m3 +
+		Delta3*NA*NB*(NA-NB)/(N*N) +
+		3*Delta*(NA*Other.m2-NB*A.m2)/N
+
+	M4 := A.m4 + Other.m4 +
+		Delta4*NA*NB*(NA*NA-NA*NB+NB*NB)/(N*N*N) +
+		6*Delta2*(NA*NA*Other.m2+NB*NB*A.m2)/(N*N) +
+		4*Delta*(NA*Other.m3-NB*A.m3)/N
+
+	A.mean = A.mean + Delta*NB/N
+	A.m2 = M2
+	A.m3 = M3
+	A.m4 = M4
+	A.count = int(N)
+
+	return A
+}
+
 // NewConcurrentAggregate
 //
 // This function creates and initializes a new ConcurrentAggregate value, ready to be used.
 func NewConcurrentAggregate() *ConcurrentAggregate {
-	return &ConcurrentAggregate{
-		mu: &sync.RWMutex{},
-	}
+
+	A := &ConcurrentAggregate{}
+	A.current.Store(NewAggregate())
+
+	return A
 }
 
 // Reset
 //
 // This function resets an existing ConcurrentAggregate back to a zero-value, readying it
 // to be used on a new random sequence.
-func (A *ConcurrentAggregate) Reset() *ConcurrentAggregate {
+func (A *ConcurrentAggregate) Reset() WelfordWriter {
 
-	A.mu.Lock()
-	defer A.mu.Unlock()
+	A.wmu.Lock()
+	defer A.wmu.Unlock()
 
-	A.Aggregate.Reset()
+	A.current.Store(NewAggregate())
 
 	return A
 }
@@ -139,44 +265,71 @@ func (A *ConcurrentAggregate) Reset() *ConcurrentAggregate {
 // Update
 //
 // This function accepts a new random sample, and updates the internal state of
-// the ConcurrentAggregate to account for the newly provided sample value.
-func (A *ConcurrentAggregate) Update(Values ...float64) *ConcurrentAggregate {
+// the ConcurrentAggregate to account for the newly provided sample value. This
+// copies the current Aggregate, applies the update to the copy, and publishes
+// it atomically, so that concurrent readers always observe a consistent,
+// fully-updated Aggregate.
+func (A *ConcurrentAggregate) Update(Values ...float64) WelfordWriter {
 
-	A.mu.Lock()
-	defer A.mu.Unlock()
+	A.wmu.Lock()
+	defer A.wmu.Unlock()
+
+	Updated := *A.current.Load()
+	Updated.Update(Values...)
+	A.current.Store(&Updated)
 
-	A.Aggregate.Update(Values...)
 	return A
+}
 
+// Snapshot
+//
+// This function returns an immutable WelfordSnapshot capturing the
+// ConcurrentAggregate's state at the moment of the call. Because the
+// returned value is never mutated, it may be read freely without
+// synchronization, and readers never block concurrent writers.
+func (A *ConcurrentAggregate) Snapshot() WelfordSnapshot {
+	return A.snapshot()
 }
 
-func (A *ConcurrentAggregate) Variance() float64 {
+func (A *ConcurrentAggregate) snapshot() *Aggregate {
 
-	A.mu.RLock()
-	defer A.mu.RUnlock()
+	Copy := *A.current.Load()
 
-	return A.Aggregate.Variance()
+	return &Copy
 }
 
-func (A *ConcurrentAggregate) SampleVariance() float64 {
+func (A *ConcurrentAggregate) Count() int {
+	return A.snapshot().Count()
+}
 
-	A.mu.RLock()
-	defer A.mu.RUnlock()
+func (A *ConcurrentAggregate) Mean() float64 {
+	return A.snapshot().Mean()
+}
 
-	return A.Aggregate.SampleVariance()
+func (A *ConcurrentAggregate) Variance() float64 {
+	return A.snapshot().Variance()
 }
 
-func (A *ConcurrentAggregate) Results() (int, float64, float64, float64) {
+func (A *ConcurrentAggregate) SampleVariance() float64 {
+	return A.snapshot().SampleVariance()
+}
 
-	A.mu.RLock()
-	defer A.mu.RUnlock()
+func (A *ConcurrentAggregate) Results() (int, float64, float64, float64) {
+	return A.snapshot().Results()
+}
 
-	return A.Aggregate.Results()
+func (A *ConcurrentAggregate) Skewness() float64 {
+	return A.snapshot().Skewness()
 }
 
-func (A *ConcurrentAggregate) String() string {
+func (A *ConcurrentAggregate) Kurtosis() float64 {
+	return A.snapshot().Kurtosis()
+}
 
-	Count, Mean, Variance, SampleVariance := A.Results()
+func (A *ConcurrentAggregate) ResultsExtended() (int, float64, float64, float64, float64, float64) {
+	return A.snapshot().ResultsExtended()
+}
 
-	return fmt.Sprintf("Count: %d, Mean: %f, Variance: %f, Sample Variance: %f", Count, Mean, Variance, SampleVariance)
+func (A *ConcurrentAggregate) String() string {
+	return A.snapshot().String()
 }
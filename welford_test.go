@@ -0,0 +1,231 @@
+package welford
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestAggregateUpdateMatchesNaive(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9, -3, 10.5}
+
+	A := NewAggregate()
+	A.Update(Data...)
+
+	N := float64(len(Data))
+	var Mean float64
+	for _, v := range Data {
+		Mean += v
+	}
+	Mean /= N
+
+	var M2 float64
+	for _, v := range Data {
+		d := v - Mean
+		M2 += d * d
+	}
+
+	if math.Abs(A.Mean()-Mean) > 1e-9 {
+		t.Fatalf("mean mismatch: got %f want %f", A.Mean(), Mean)
+	}
+	if math.Abs(A.Variance()-M2/N) > 1e-9 {
+		t.Fatalf("variance mismatch: got %f want %f", A.Variance(), M2/N)
+	}
+}
+
+func TestAggregateCombineMatchesDirectUpdate(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9, -3, 10.5, 6, 1}
+
+	Direct := NewAggregate()
+	Direct.Update(Data...)
+
+	Split := len(Data) / 2
+	A := NewAggregate()
+	A.Update(Data[:Split]...)
+	B := NewAggregate()
+	B.Update(Data[Split:]...)
+	A.Combine(B)
+
+	if A.Count() != Direct.Count() {
+		t.Fatalf("count mismatch: got %d want %d", A.Count(), Direct.Count())
+	}
+	if math.Abs(A.Mean()-Direct.Mean()) > 1e-9 {
+		t.Fatalf("mean mismatch: got %f want %f", A.Mean(), Direct.Mean())
+	}
+	if math.Abs(A.Variance()-Direct.Variance()) > 1e-9 {
+		t.Fatalf("variance mismatch: got %f want %f", A.Variance(), Direct.Variance())
+	}
+}
+
+func TestAggregateCombineWithEmpty(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(1, 2, 3)
+
+	Empty := NewAggregate()
+	A.Combine(Empty)
+
+	if A.Count() != 3 {
+		t.Fatalf("expected combining with an empty Aggregate to be a no-op, got count %d", A.Count())
+	}
+
+	B := NewAggregate()
+	B.Combine(A)
+
+	if math.Abs(B.Mean()-A.Mean()) > 1e-9 || B.Count() != A.Count() {
+		t.Fatalf("expected combining an empty Aggregate with a populated one to adopt its state")
+	}
+}
+
+func TestAggregateReset(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(1, 2, 3)
+	A.Reset()
+
+	if Count, Mean, Variance, SampleVariance := A.Results(); Count != 0 || Mean != 0 || Variance != 0 || SampleVariance != 0 {
+		t.Fatalf("expected zero-value after Reset, got (%d, %f, %f, %f)", Count, Mean, Variance, SampleVariance)
+	}
+}
+
+func naiveSkewnessKurtosis(Data []float64) (float64, float64) {
+
+	N := float64(len(Data))
+	var Mean float64
+	for _, v := range Data {
+		Mean += v
+	}
+	Mean /= N
+
+	var M2, M3, M4 float64
+	for _, v := range Data {
+		d := v - Mean
+		M2 += d * d
+		M3 += d * d * d
+		M4 += d * d * d * d
+	}
+
+	return math.Sqrt(N) * M3 / math.Pow(M2, 1.5), N*M4/(M2*M2) - 3
+}
+
+func TestAggregateSkewnessKurtosisMatchNaive(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9, 3, 1, 8, 6, 2, 5, 9, 10, -3, 4.5}
+
+	A := NewAggregate()
+	A.Update(Data...)
+
+	WantSkew, WantKurt := naiveSkewnessKurtosis(Data)
+
+	if math.Abs(A.Skewness()-WantSkew) > 1e-6 {
+		t.Fatalf("skewness mismatch: got %f want %f", A.Skewness(), WantSkew)
+	}
+	if math.Abs(A.Kurtosis()-WantKurt) > 1e-6 {
+		t.Fatalf("kurtosis mismatch: got %f want %f", A.Kurtosis(), WantKurt)
+	}
+}
+
+func TestAggregateCombinePreservesMoments(t *testing.T) {
+
+	Data := []float64{2, 4, 4, 4, 5, 5, 7, 9, 3, 1, 8, 6, 2, 5, 9, 10, -3, 4.5}
+
+	Direct := NewAggregate()
+	Direct.Update(Data...)
+
+	Split := len(Data) / 3
+	A := NewAggregate()
+	A.Update(Data[:Split]...)
+	B := NewAggregate()
+	B.Update(Data[Split : 2*Split]...)
+	C := NewAggregate()
+	C.Update(Data[2*Split:]...)
+
+	A.Combine(B)
+	A.Combine(C)
+
+	if math.Abs(A.Skewness()-Direct.Skewness()) > 1e-6 {
+		t.Fatalf("skewness mismatch: got %f want %f", A.Skewness(), Direct.Skewness())
+	}
+	if math.Abs(A.Kurtosis()-Direct.Kurtosis()) > 1e-6 {
+		t.Fatalf("kurtosis mismatch: got %f want %f", A.Kurtosis(), Direct.Kurtosis())
+	}
+}
+
+func TestAggregateResultsExtended(t *testing.T) {
+
+	A := NewAggregate()
+	A.Update(1, 2, 3, 4, 5)
+
+	Count, Mean, Variance, SampleVariance, Skewness, Kurtosis := A.ResultsExtended()
+
+	if Count != A.Count() || Mean != A.Mean() || Variance != A.Variance() || SampleVariance != A.SampleVariance() ||
+		Skewness != A.Skewness() || Kurtosis != A.Kurtosis() {
+		t.Fatalf("ResultsExtended disagrees with individual accessors")
+	}
+}
+
+func TestConcurrentAggregateRace(t *testing.T) {
+
+	A := NewConcurrentAggregate()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(v float64) {
+			defer wg.Done()
+			A.Update(v)
+		}(float64(i))
+	}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = A.Snapshot()
+			_ = A.Count()
+			_ = A.Mean()
+			_ = A.String()
+		}()
+	}
+	wg.Wait()
+
+	if A.Count() != 100 {
+		t.Fatalf("expected 100 updates, got %d", A.Count())
+	}
+}
+
+func TestConcurrentAggregateSnapshotIsImmutable(t *testing.T) {
+
+	A := NewConcurrentAggregate()
+	A.Update(1, 2, 3)
+
+	Snapshot := A.Snapshot()
+
+	A.Update(100, 200, 300)
+
+	if Snapshot.Count() != 3 {
+		t.Fatalf("expected Snapshot to be unaffected by later Update calls, got count %d", Snapshot.Count())
+	}
+	if A.Count() != 6 {
+		t.Fatalf("expected live ConcurrentAggregate to reflect later Update calls, got count %d", A.Count())
+	}
+}
+
+func TestWelfordInterfacesAreSatisfiable(t *testing.T) {
+
+	var (
+		_ WelfordWriter    = NewAggregate()
+		_ WelfordAggregate = NewAggregate()
+		_ WelfordWriter    = NewConcurrentAggregate()
+		_ WelfordAggregate = NewConcurrentAggregate()
+		_ WelfordWriter    = NewWeightedAggregate()
+		_ WelfordAggregate = NewWeightedAggregate()
+		_ WelfordWriter    = NewConcurrentWeightedAggregate()
+		_ WelfordAggregate = NewConcurrentWeightedAggregate()
+		_ WelfordWriter    = NewDecayingAggregate(0.9)
+		_ WelfordAggregate = NewDecayingAggregate(0.9)
+		_ WelfordWriter    = NewConcurrentDecayingAggregate(0.9)
+		_ WelfordAggregate = NewConcurrentDecayingAggregate(0.9)
+	)
+}